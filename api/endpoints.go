@@ -0,0 +1,34 @@
+package api
+
+import "fmt"
+
+// Endpoints is the set of server paths used to fetch assignments.
+type Endpoints struct {
+	Current  string
+	Next     string
+	Restore  string
+	Exercise string
+}
+
+// DefaultEndpoints are the paths exercism.io has always exposed.
+var DefaultEndpoints = Endpoints{
+	Current:  "/api/v1/user/assignments/current",
+	Next:     "/api/v1/user/assignments/next",
+	Restore:  "/api/v1/user/assignments/restore",
+	Exercise: "/api/v1/assignments",
+}
+
+// For resolves the endpoint to fetch a specific exercise, appending args
+// (e.g. track and slug) to the Exercise endpoint. With no args it
+// returns Current, the endpoint for "what should I work on next".
+func (e Endpoints) For(args ...string) string {
+	if len(args) == 0 {
+		return e.Current
+	}
+
+	path := e.Exercise
+	for _, arg := range args {
+		path = fmt.Sprintf("%s/%s", path, arg)
+	}
+	return path
+}
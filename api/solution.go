@@ -0,0 +1,11 @@
+package api
+
+// Solution is the payload posted when submitting an exercise. It mirrors
+// the file layout of a fetched Problem, mapping each file's path
+// (relative to the exercise directory) to its contents, so solutions
+// that span multiple files submit the same way single-file ones do.
+type Solution struct {
+	Key   string            `json:"key"`
+	Path  string            `json:"path"`
+	Files map[string]string `json:"files"`
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Capabilities asks hostname which transfer adapters it supports, via the
+// /api/v1/user/capabilities probe.
+func Capabilities(ctx context.Context, c *Client, hostname string) ([]string, error) {
+	url := fmt.Sprintf("%s/api/v1/user/capabilities?key=%s", hostname, c.APIKey)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching capabilities: [%v]", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// Servers that predate the capabilities endpoint 404; treat that
+		// the same as "basic only" rather than failing the submission.
+		return []string{"basic"}, nil
+	}
+
+	var cr struct {
+		TransferAdapters []string `json:"transfer_adapters"`
+	}
+	if err := json.Unmarshal(body, &cr); err != nil {
+		return nil, fmt.Errorf("Error parsing capabilities response: [%v]", err)
+	}
+	return cr.TransferAdapters, nil
+}
@@ -0,0 +1,121 @@
+// Package client provides the HTTP transport used to talk to exercism.io,
+// wrapping a configurable http.RoundTripper with the headers and retry
+// behavior every API call needs.
+package client
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MaxRetries is the number of attempts a request gets before Do gives up
+// and returns the last response (for a 5xx or 429) or the last error.
+const MaxRetries = 3
+
+// Client sends requests to exercism.io, automatically attaching the
+// User-Agent and Authorization headers and retrying transient failures
+// with exponential backoff.
+type Client struct {
+	// Transport is the underlying http.RoundTripper. It defaults to
+	// http.DefaultTransport, but tests can swap in a fake.
+	Transport http.RoundTripper
+
+	UserAgent string
+	APIKey    string
+}
+
+// New builds a Client that sends UserAgent and, when apiKey is non-empty,
+// an Authorization header on every request.
+func New(userAgent, apiKey string) *Client {
+	return &Client{
+		Transport: http.DefaultTransport,
+		UserAgent: userAgent,
+		APIKey:    apiKey,
+	}
+}
+
+// Do sends req, retrying on 5xx responses and network errors with
+// exponential backoff, and honoring a Retry-After header on 429.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", c.UserAgent)
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	}
+
+	httpClient := &http.Client{Transport: c.Transport}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt < MaxRetries; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// The previous attempt read the body to EOF, so it must be
+			// rewound before resending. If the request can't tell us how
+			// (GetBody is only set for bodies NewRequest knows how to
+			// replay, e.g. bytes.Reader/bytes.Buffer), give up rather
+			// than silently retrying with an empty body.
+			if req.GetBody == nil {
+				break
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+
+		resp, err = httpClient.Do(req)
+		if err != nil {
+			if attempt == MaxRetries-1 {
+				break
+			}
+			time.Sleep(backoff(attempt))
+			continue
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt == MaxRetries-1 {
+			return resp, err
+		}
+
+		wait := backoff(attempt)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait = retryAfter(resp, attempt)
+		}
+		drain(resp)
+		time.Sleep(wait)
+	}
+
+	return resp, err
+}
+
+// drain discards and closes resp.Body so the connection can be reused,
+// since we're about to retry and throw this response away.
+func drain(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+// backoff returns an exponential delay with jitter for the given attempt,
+// starting at roughly 200ms.
+func backoff(attempt int) time.Duration {
+	base := 200 * time.Millisecond
+	delay := base << uint(attempt)
+	jitter := time.Duration(rand.Int63n(int64(base)))
+	return delay + jitter
+}
+
+// retryAfter honors a Retry-After header expressed in seconds, falling
+// back to the regular backoff schedule if it's absent or malformed.
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return backoff(attempt)
+}
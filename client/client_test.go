@@ -0,0 +1,129 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// countingTransport always returns err, counting how many times
+// RoundTrip was called.
+type countingTransport struct {
+	calls int
+	err   error
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.calls++
+	return nil, t.err
+}
+
+func TestDoNetworkErrorExhaustsRetries(t *testing.T) {
+	transport := &countingTransport{err: errors.New("connection refused")}
+	c := &Client{Transport: transport, UserAgent: "test-agent"}
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	_, err := c.Do(req)
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if transport.calls != MaxRetries {
+		t.Errorf("expected %d attempts, got %d", MaxRetries, transport.calls)
+	}
+}
+
+func TestDoServerErrorExhaustsRetriesAndReturnsBody(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":"boom"}`))
+	}))
+	defer server.Close()
+
+	c := &Client{Transport: http.DefaultTransport, UserAgent: "test-agent"}
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != MaxRetries {
+		t.Errorf("expected %d attempts, got %d", MaxRetries, calls)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected to read the final response body, got: %v", err)
+	}
+	if string(body) != `{"error":"boom"}` {
+		t.Errorf("expected the error payload, got %q", body)
+	}
+}
+
+func TestDoHonorsRetryAfter(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < MaxRetries {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{Transport: http.DefaultTransport, UserAgent: "test-agent"}
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200 after retrying, got %d", resp.StatusCode)
+	}
+	if calls != MaxRetries {
+		t.Errorf("expected %d attempts, got %d", MaxRetries, calls)
+	}
+}
+
+func TestDoRewindsBodyOnRetry(t *testing.T) {
+	var bodies []string
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if calls < MaxRetries {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := &Client{Transport: http.DefaultTransport, UserAgent: "test-agent"}
+	req, err := http.NewRequest("POST", server.URL, bytes.NewReader([]byte("solution body")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+	for i, body := range bodies {
+		if body != "solution body" {
+			t.Errorf("attempt %d: expected the body to be resent intact, got %q", i, body)
+		}
+	}
+}
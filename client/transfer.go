@@ -0,0 +1,163 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/exercism/cli/api"
+)
+
+// TransferAdapter uploads an api.Solution to url, returning the raw HTTP
+// response so callers can decode it the same way regardless of adapter.
+type TransferAdapter interface {
+	// Name identifies the adapter as advertised by the
+	// /api/v1/user/capabilities endpoint.
+	Name() string
+	Upload(ctx context.Context, c *Client, url string, s api.Solution) (*http.Response, error)
+}
+
+// Adapters is every TransferAdapter this client knows how to speak,
+// keyed by the capability name the server advertises for it.
+var Adapters = map[string]TransferAdapter{
+	"basic":     BasicAdapter{},
+	"multipart": MultipartAdapter{},
+	"chunked":   ChunkedAdapter{},
+}
+
+// SelectAdapter picks the best adapter this client supports out of the
+// server's advertised capabilities, preferring chunked > multipart >
+// basic, and falling back to BasicAdapter if the server advertises
+// nothing we recognize.
+func SelectAdapter(capabilities []string) TransferAdapter {
+	preference := []string{"chunked", "multipart", "basic"}
+	offered := map[string]bool{}
+	for _, cap := range capabilities {
+		offered[cap] = true
+	}
+
+	for _, name := range preference {
+		if offered[name] {
+			return Adapters[name]
+		}
+	}
+	return BasicAdapter{}
+}
+
+// BasicAdapter posts the solution as a single JSON body keyed by file
+// path. It's the simplest adapter and the one every server is assumed to
+// support, so it's the fallback when no other capability is advertised.
+type BasicAdapter struct{}
+
+func (BasicAdapter) Name() string { return "basic" }
+
+func (BasicAdapter) Upload(ctx context.Context, c *Client, url string, s api.Solution) (*http.Response, error) {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	return c.Do(req)
+}
+
+// MultipartAdapter posts every file in the submission as a part of a
+// multipart/form-data request, for solutions that span multiple files.
+type MultipartAdapter struct{}
+
+func (MultipartAdapter) Name() string { return "multipart" }
+
+func (MultipartAdapter) Upload(ctx context.Context, c *Client, url string, s api.Solution) (*http.Response, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	if err := w.WriteField("key", s.Key); err != nil {
+		return nil, err
+	}
+
+	for path, text := range s.Files {
+		part, err := w.CreateFormFile("files", path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(text)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return c.Do(req)
+}
+
+// ChunkedAdapter negotiates an upload URL and then streams each file to
+// it, for solutions too large to comfortably hold in a single request.
+type ChunkedAdapter struct{}
+
+func (ChunkedAdapter) Name() string { return "chunked" }
+
+func (ChunkedAdapter) Upload(ctx context.Context, c *Client, url string, s api.Solution) (*http.Response, error) {
+	negotiation := struct {
+		Key string `json:"key"`
+	}{Key: s.Key}
+	payload, err := json.Marshal(negotiation)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", url+"/negotiate", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var negotiated struct {
+		UploadURL string `json:"upload_url"`
+	}
+	if err := json.Unmarshal(body, &negotiated); err != nil {
+		return nil, fmt.Errorf("Error negotiating upload URL: [%v]", err)
+	}
+
+	var last *http.Response
+	for path, text := range s.Files {
+		req, err := http.NewRequest("PUT", negotiated.UploadURL+"/"+path, bytes.NewReader([]byte(text)))
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		last, err = c.Do(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return last, nil
+}
@@ -0,0 +1,89 @@
+// Package config reads and writes the on-disk settings — API key,
+// exercises directory, and API host — that every other command
+// depends on.
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+)
+
+// Hostname is the default exercism.io API host.
+const Hostname = "http://exercism.io"
+
+// ProblemsHost is where the web UI for browsing problems lives.
+const ProblemsHost = "http://exercism.io"
+
+// Config holds the settings read from, and written to, the user's
+// config file.
+type Config struct {
+	APIKey       string `json:"apiKey"`
+	Dir          string `json:"dir"`
+	Hostname     string `json:"hostname"`
+	ProblemsHost string `json:"problemsHost"`
+
+	// File is where this Config was read from (or will be written to).
+	// It's not persisted as part of the JSON itself.
+	File string `json:"-"`
+}
+
+// Home returns the current user's home directory.
+func Home() string {
+	if u, err := user.Current(); err == nil && u.HomeDir != "" {
+		return u.HomeDir
+	}
+	return os.Getenv("HOME")
+}
+
+// File is the default path to the config file.
+func File() string {
+	return filepath.Join(Home(), ".exercism.json")
+}
+
+// Read loads the config at path, or at the default location if path is
+// empty. A missing file isn't an error — it just yields a Config with
+// the usual defaults, pointed at where it would be written.
+func Read(path string) (*Config, error) {
+	if path == "" {
+		path = File()
+	}
+
+	c := &Config{
+		Hostname:     Hostname,
+		ProblemsHost: ProblemsHost,
+		Dir:          filepath.Join(Home(), "exercism"),
+		File:         path,
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, err
+	}
+	c.File = path
+
+	return c, nil
+}
+
+// Write saves c to c.File.
+func (c *Config) Write() error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.File, b, 0644)
+}
+
+// IsAuthenticated reports whether an API key has been configured.
+func (c *Config) IsAuthenticated() bool {
+	return c.APIKey != ""
+}
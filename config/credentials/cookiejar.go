@@ -0,0 +1,68 @@
+package credentials
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// FromGitCookieFile reads the cookie file configured via `git config
+// --get http.cookiefile`, a Netscape-format cookie jar, and returns the
+// value of the cookie that authenticates hostname. It matches either the
+// exact host or a domain-wide entry such as ".exercism.io". It returns
+// "" if git has no cookiefile configured or none of its entries match.
+func FromGitCookieFile(hostname string) (string, error) {
+	path, err := gitCookieFilePath()
+	if err != nil || path == "" {
+		return "", err
+	}
+	return cookieFromFile(path, hostname)
+}
+
+// cookieFromFile parses the Netscape cookie jar at path and returns the
+// value of the cookie matching hostname.
+func cookieFromFile(path, hostname string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	host := hostOf(hostname)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 7 {
+			continue
+		}
+
+		domain := strings.TrimPrefix(fields[0], ".")
+		domainWide := strings.HasPrefix(fields[0], ".")
+		if domain == host || (domainWide && strings.HasSuffix(host, "."+domain)) {
+			return fields[6], nil
+		}
+	}
+
+	return "", scanner.Err()
+}
+
+// gitCookieFilePath returns the path git has configured for
+// http.cookiefile, or "" if it has none.
+func gitCookieFilePath() (string, error) {
+	out, err := exec.Command("git", "config", "--get", "http.cookiefile").Output()
+	if err != nil {
+		// `git config --get` exits non-zero when the key is unset; that's
+		// not a real error, just "no cookie file configured".
+		return "", nil
+	}
+	return strings.TrimSpace(string(out)), nil
+}
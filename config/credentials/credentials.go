@@ -0,0 +1,31 @@
+// Package credentials discovers an exercism.io API key from the
+// standard places a user might already have one stashed, so they don't
+// have to pass --key by hand when configuring the CLI for the first
+// time.
+package credentials
+
+import "net/url"
+
+// Discover looks up an API key for hostname, checking $HOME/.netrc
+// first and then the cookie file configured via `git config --get
+// http.cookiefile`. It returns "" if neither source has a match.
+func Discover(home, hostname string) (string, error) {
+	key, err := FromNetrc(home, hostname)
+	if err != nil {
+		return "", err
+	}
+	if key != "" {
+		return key, nil
+	}
+
+	return FromGitCookieFile(hostname)
+}
+
+// hostOf returns the host portion of hostname, which may be a bare host
+// or a full URL such as "https://exercism.io".
+func hostOf(hostname string) string {
+	if u, err := url.Parse(hostname); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return hostname
+}
@@ -0,0 +1,53 @@
+package credentials
+
+import "testing"
+
+func TestFromNetrc(t *testing.T) {
+	key, err := FromNetrc("testdata", "exercism.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "s3cr3t-api-key" {
+		t.Errorf("expected s3cr3t-api-key, got %q", key)
+	}
+}
+
+func TestFromNetrcNoMatch(t *testing.T) {
+	key, err := FromNetrc("testdata", "unknown-host.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		t.Errorf("expected no key, got %q", key)
+	}
+}
+
+func TestFromNetrcMissingFile(t *testing.T) {
+	key, err := FromNetrc("testdata/does-not-exist", "exercism.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		t.Errorf("expected no key, got %q", key)
+	}
+}
+
+func TestCookieFromFile(t *testing.T) {
+	key, err := cookieFromFile("testdata/cookies.txt", "exercism.io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "s3cr3t-cookie-key" {
+		t.Errorf("expected s3cr3t-cookie-key, got %q", key)
+	}
+}
+
+func TestCookieFromFileExactHostOnly(t *testing.T) {
+	key, err := cookieFromFile("testdata/cookies.txt", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "not-the-key" {
+		t.Errorf("expected not-the-key, got %q", key)
+	}
+}
@@ -0,0 +1,42 @@
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FromNetrc scans home/.netrc for a machine entry matching hostname's
+// host and returns its password, which exercism.io users configure as
+// their API key. It returns "" if there's no .netrc or no matching
+// entry.
+func FromNetrc(home, hostname string) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(home, ".netrc"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	host := hostOf(hostname)
+	fields := strings.Fields(string(b))
+
+	var machine, password string
+	for i, field := range fields {
+		if i+1 >= len(fields) {
+			break
+		}
+		switch field {
+		case "machine":
+			machine = fields[i+1]
+		case "password":
+			if machine == host {
+				password = fields[i+1]
+			}
+		}
+	}
+
+	return password, nil
+}
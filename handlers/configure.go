@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/codegangsta/cli"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/config/credentials"
+)
+
+// Configure writes config values to the user's config file. Flags that
+// aren't passed leave the existing value alone. If --key is omitted and
+// the user isn't already authenticated, it tries to discover an API key
+// from .netrc or the git cookiefile before giving up and leaving the
+// key blank.
+func Configure(ctx *cli.Context) {
+	c, err := config.Read(ctx.GlobalString("config"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if dir := ctx.String("dir"); dir != "" {
+		c.Dir = dir
+	}
+	if host := ctx.String("host"); host != "" {
+		c.Hostname = host
+	}
+
+	if key := ctx.String("key"); key != "" {
+		c.APIKey = key
+	} else if !c.IsAuthenticated() {
+		discovered, err := credentials.Discover(config.Home(), c.Hostname)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if discovered != "" {
+			c.APIKey = discovered
+		}
+	}
+
+	if err := c.Write(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Your credentials have been written to %s\n", c.File)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/codegangsta/cli"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/output"
+)
+
+// Debug prints the information we'd ask for in a bug report: where the
+// config file and exercises directory live, and what Go/OS built the
+// binary.
+func Debug(ctx *cli.Context) {
+	out := output.New(ctx.GlobalString("output"))
+
+	c, err := config.Read(ctx.GlobalString("config"))
+	if err != nil {
+		out.Error(output.Event{Name: "debug", Message: err.Error()})
+		return
+	}
+
+	out.Notice(output.Event{Name: "debug", Message: fmt.Sprintf("Go version: %s", runtime.Version())})
+	out.Notice(output.Event{Name: "debug", Message: fmt.Sprintf("OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH)})
+	out.Notice(output.Event{Name: "debug", Message: fmt.Sprintf("Config file: %s", c.File)})
+	out.Notice(output.Event{Name: "debug", Message: fmt.Sprintf("Exercises dir: %s", c.Dir)})
+	out.Notice(output.Event{Name: "debug", Message: fmt.Sprintf("API host: %s", c.Hostname)})
+}
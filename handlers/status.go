@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/codegangsta/cli"
+	"github.com/exercism/cli/config"
+	"github.com/exercism/cli/internal/workspace"
+)
+
+// Status walks the cached state for the user's exercises directory and
+// reports which files are new or updated since a previous fetch, and
+// which are dirty (locally modified) or stale (recorded but missing)
+// relative to what was last fetched. Status gives users a preview of
+// what `submit` would see, and what a `fetch` already pulled down,
+// before anything gets overwritten.
+func Status(ctx *cli.Context) {
+	c, err := config.Read(ctx.GlobalString("config"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	state, err := workspace.LoadState(c.Dir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	grouped := map[workspace.FileStatus][]string{}
+	for path, cached := range state.Files {
+		local := filepath.Join(c.Dir, path)
+		text, err := ioutil.ReadFile(local)
+		if err != nil {
+			grouped[workspace.StatusStale] = append(grouped[workspace.StatusStale], path)
+			continue
+		}
+		if workspace.HashOf(string(text)) != cached.Hash {
+			grouped[workspace.StatusDirty] = append(grouped[workspace.StatusDirty], path)
+			continue
+		}
+		if cached.Status == workspace.StatusNew || cached.Status == workspace.StatusUpdated {
+			grouped[cached.Status] = append(grouped[cached.Status], path)
+		}
+	}
+
+	for _, status := range []workspace.FileStatus{workspace.StatusNew, workspace.StatusUpdated, workspace.StatusDirty, workspace.StatusStale} {
+		paths := grouped[status]
+		if len(paths) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", status)
+		for _, path := range paths {
+			fmt.Printf("  %s\n", path)
+		}
+	}
+
+	if len(grouped) == 0 {
+		fmt.Println("Everything is up to date.")
+	}
+}
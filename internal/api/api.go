@@ -0,0 +1,172 @@
+// Package api talks to the exercism.io HTTP API: fetching assignments,
+// submitting solutions, and deleting the most recent submission. It's
+// internal because its only job is to back the command wiring in
+// package main; the wire types it exchanges live in the public api
+// package.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/exercism/cli/api"
+	"github.com/exercism/cli/client"
+	"github.com/exercism/cli/config"
+)
+
+// Assignment is a single exercise returned by FetchAssignments.
+type Assignment struct {
+	Track   string
+	Slug    string
+	Files   map[string]string
+	IsFresh bool `json:"fresh"`
+}
+
+// SubmitResponse is the server's acknowledgement of a submitted
+// solution.
+type SubmitResponse struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	Language       string `json:"language"`
+	Exercise       string `json:"exercise"`
+	SubmissionPath string `json:"submission_path"`
+	Error          string `json:"error"`
+}
+
+// Client calls the exercism.io API on behalf of a configured user.
+type Client struct {
+	Config    *config.Config
+	UserAgent string
+}
+
+// NewClient builds a Client for c, identifying itself as userAgent.
+func NewClient(c *config.Config, userAgent string) *Client {
+	return &Client{Config: c, UserAgent: userAgent}
+}
+
+func (a *Client) httpClient() *client.Client {
+	return client.New(a.UserAgent, a.Config.APIKey)
+}
+
+// FetchAssignments retrieves the assignments at path, e.g. one of
+// api.DefaultEndpoints' Current/Next/Restore/Exercise paths.
+func (a *Client) FetchAssignments(ctx context.Context, path string) ([]Assignment, error) {
+	url := fmt.Sprintf("%s%s?key=%s", a.Config.Hostname, path, a.Config.APIKey)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching assignments: [%v]", err)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching assignments: [%v]", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &apiError); err != nil {
+			return nil, fmt.Errorf("Error parsing API response: [%v]", err)
+		}
+		return nil, fmt.Errorf("Error fetching assignments. HTTP Status Code: %d\n%s", resp.StatusCode, apiError.Error)
+	}
+
+	var fr struct {
+		Assignments []Assignment
+	}
+	if err := json.Unmarshal(body, &fr); err != nil {
+		return nil, fmt.Errorf("Error parsing API response: [%v]", err)
+	}
+
+	return fr.Assignments, nil
+}
+
+// UnsubmitAssignment deletes the most recently submitted iteration.
+func (a *Client) UnsubmitAssignment(ctx context.Context) error {
+	url := fmt.Sprintf("%s/api/v1/user/assignments?key=%s", a.Config.Hostname, a.Config.APIKey)
+
+	req, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("Error destroying submission: [%v]", err)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		var ur struct {
+			Error string
+		}
+		if err := json.Unmarshal(body, &ur); err != nil {
+			return err
+		}
+		return fmt.Errorf("Status: %d, Error: %v", resp.StatusCode, ur.Error)
+	}
+
+	return nil
+}
+
+// SubmitAssignment submits files, keyed by path relative to root, as
+// the solution rooted at root. It probes the server's capabilities to
+// pick a TransferAdapter before uploading.
+func (a *Client) SubmitAssignment(ctx context.Context, root string, files map[string]string) (*SubmitResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/user/assignments", a.Config.Hostname)
+
+	cl := a.httpClient()
+
+	capabilities, err := client.Capabilities(ctx, cl, a.Config.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	adapter := client.SelectAdapter(capabilities)
+
+	solution := api.Solution{
+		Key:   a.Config.APIKey,
+		Path:  root,
+		Files: files,
+	}
+
+	resp, err := adapter.Upload(ctx, cl, url, solution)
+	if err != nil {
+		return nil, fmt.Errorf("Error posting assignment: [%v]", err)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var r SubmitResponse
+	if resp.StatusCode != http.StatusCreated {
+		if err := json.Unmarshal(body, &r); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Status: %d, Error: %v", resp.StatusCode, r)
+	}
+
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("Error parsing API response: [%v]", err)
+	}
+	return &r, nil
+}
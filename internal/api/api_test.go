@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/exercism/cli/config"
+)
+
+func testClient(handler http.Handler) (*Client, func()) {
+	server := httptest.NewServer(handler)
+	c := &config.Config{Hostname: server.URL, APIKey: "abc123"}
+	return NewClient(c, "test-agent"), server.Close
+}
+
+func TestFetchAssignments(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+		wantLen int
+	}{
+		{
+			name:    "success",
+			status:  http.StatusOK,
+			body:    `{"assignments":[{"Track":"go","Slug":"leap"}]}`,
+			wantLen: 1,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `{"error":"boom"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, cleanup := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer cleanup()
+
+			assignments, err := api.FetchAssignments(context.Background(), "/api/v1/user/assignments/current")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(assignments) != tt.wantLen {
+				t.Errorf("expected %d assignments, got %d", tt.wantLen, len(assignments))
+			}
+		})
+	}
+}
+
+func TestUnsubmitAssignment(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "success", status: http.StatusNoContent},
+		{name: "not found", status: http.StatusNotFound, body: `{"Error":"nothing to delete"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, cleanup := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					t.Errorf("expected DELETE, got %s", r.Method)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer cleanup()
+
+			err := api.UnsubmitAssignment(context.Background())
+			if tt.wantErr && err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestSubmitAssignment(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{name: "success", status: http.StatusCreated, body: `{"id":"1","status":"ok"}`},
+		{name: "rejected", status: http.StatusUnprocessableEntity, body: `{"error":"invalid"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, cleanup := testClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/user/capabilities" {
+					fmt.Fprint(w, `{"transfer_adapters":["basic"]}`)
+					return
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer cleanup()
+
+			resp, err := api.SubmitAssignment(context.Background(), "go/leap", map[string]string{"leap.go": "package leap"})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.ID != "1" {
+				t.Errorf("expected id 1, got %v", resp.ID)
+			}
+		})
+	}
+}
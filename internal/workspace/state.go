@@ -0,0 +1,124 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// stateFile is where per-exercise file metadata is cached, relative to the
+// exercises directory (config.Dir).
+const stateFile = ".exercism/state.json"
+
+// FileStatus describes how a cached file compares to what's on disk and
+// what the server last sent.
+type FileStatus string
+
+const (
+	// StatusNew means the file has never been recorded before.
+	StatusNew FileStatus = "new"
+	// StatusUpdated means the remote hash no longer matches the cached one.
+	StatusUpdated FileStatus = "updated"
+	// StatusDirty means the file on disk no longer matches the cached hash,
+	// i.e. the user has edited it since it was fetched.
+	StatusDirty FileStatus = "dirty"
+	// StatusStale means the file is recorded but missing on disk.
+	StatusStale FileStatus = "stale"
+	// StatusCurrent means the file matches what was last fetched.
+	StatusCurrent FileStatus = "current"
+)
+
+// FileState is the cached metadata for a single fetched file.
+type FileState struct {
+	Hash      string    `json:"hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Status is the FileStatus Check returned the last time this file
+	// was written (StatusNew, StatusUpdated or StatusStale), so a later `status` run
+	// can report it without needing to re-fetch from the server.
+	Status FileStatus `json:"status"`
+}
+
+// State is the on-disk cache of file metadata for every exercise that has
+// been fetched into a given directory. It lets Fetch/Restore skip files
+// that haven't changed remotely, and lets Save tell a locally-modified
+// file apart from one that's simply missing.
+type State struct {
+	Files map[string]FileState `json:"files"`
+}
+
+// LoadState reads the cached metadata from dir, returning an empty State
+// if no cache file exists yet.
+func LoadState(dir string) (*State, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, stateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{Files: map[string]FileState{}}, nil
+		}
+		return nil, err
+	}
+
+	var s State
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	if s.Files == nil {
+		s.Files = map[string]FileState{}
+	}
+	return &s, nil
+}
+
+// Save writes the state back under dir, creating .exercism if necessary.
+func (s *State) Save(dir string) error {
+	path := filepath.Join(dir, stateFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+// Check compares text, the file's remote contents, against the cached
+// hash for path and whatever is currently on disk at localPath. It does
+// not mutate the cache.
+func (s *State) Check(path, text, localPath string) FileStatus {
+	cached, ok := s.Files[path]
+	if !ok {
+		return StatusNew
+	}
+
+	if local, err := ioutil.ReadFile(localPath); err == nil {
+		if HashOf(string(local)) != cached.Hash {
+			return StatusDirty
+		}
+	} else if os.IsNotExist(err) {
+		return StatusStale
+	}
+
+	if HashOf(text) != cached.Hash {
+		return StatusUpdated
+	}
+	return StatusCurrent
+}
+
+// Record stores the hash of text, the file's remote contents, as the new
+// baseline for path, along with the status Check returned for it, so a
+// later `status` run can tell new/updated files apart from ones that
+// were simply re-fetched unchanged.
+func (s *State) Record(path, text string, status FileStatus) {
+	s.Files[path] = FileState{Hash: HashOf(text), UpdatedAt: time.Now(), Status: status}
+}
+
+// HashOf returns the digest State uses to detect whether a file's
+// contents have changed.
+func HashOf(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
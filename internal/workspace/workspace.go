@@ -0,0 +1,181 @@
+// Package workspace is where fetched exercises live on disk: saving new
+// files, telling a test file apart from a solution, collecting a
+// directory's solution files, and resolving paths relative to it.
+package workspace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/exercism/cli/internal/api"
+	"github.com/exercism/cli/output"
+)
+
+var testExtensions = map[string]string{
+	"ruby":    "_test.rb",
+	"js":      ".spec.js",
+	"elixir":  "_test.exs",
+	"clojure": "_test.clj",
+	"python":  "_test.py",
+	"go":      "_test.go",
+	"haskell": "_test.hs",
+	"cpp":     "_test.cpp",
+}
+
+// exercismIgnoreFile is a gitignore-style list of patterns, relative to
+// the exercise directory, that CollectSolutionFiles should never pick
+// up even when walking a whole directory.
+const exercismIgnoreFile = ".exercismignore"
+
+// IsTest reports whether filename looks like a test file for any
+// supported track, based on its extension.
+func IsTest(filename string) bool {
+	for _, ext := range testExtensions {
+		if strings.LastIndex(filename, ext) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// AbsolutePath resolves path to an absolute, symlink-free location.
+func AbsolutePath(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(path)
+}
+
+// SaveAssignment writes a's files under dir, consulting the cached
+// state so unchanged files are left alone, and reports what happened
+// through out.
+func SaveAssignment(dir string, a api.Assignment, out *output.Writer) error {
+	root := fmt.Sprintf("%s/%s/%s", dir, a.Track, a.Slug)
+
+	state, err := LoadState(dir)
+	if err != nil {
+		return fmt.Errorf("Error reading cached state: [%v]", err)
+	}
+
+	dirty := false
+	for name, text := range a.Files {
+		file := fmt.Sprintf("%s/%s", root, name)
+		path := fmt.Sprintf("%s/%s/%s", a.Track, a.Slug, name)
+
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return fmt.Errorf("Error making directory %v: [%v]", filepath.Dir(file), err)
+		}
+
+		status := state.Check(path, text, file)
+		switch status {
+		case StatusDirty:
+			dirty = true
+			continue
+		case StatusCurrent:
+			continue
+		}
+
+		if err := ioutil.WriteFile(file, []byte(text), 0644); err != nil {
+			return fmt.Errorf("Error writing file %v: [%v]", name, err)
+		}
+		state.Record(path, text, status)
+	}
+
+	if err := state.Save(dir); err != nil {
+		return fmt.Errorf("Error writing cached state: [%v]", err)
+	}
+
+	fresh := " "
+	if a.IsFresh {
+		fresh = "*"
+	}
+	if dirty {
+		fresh = "!"
+	}
+
+	event := output.Event{Name: "saved", Track: a.Track, Slug: a.Slug, Path: root, Message: fmt.Sprintf("%s %s - %s", fresh, a.Track, a.Slug)}
+	if dirty {
+		out.Warning(event)
+	} else {
+		out.Notice(event)
+	}
+
+	return nil
+}
+
+// CollectSolutionFiles walks dir and returns every file under it, keyed
+// by its path relative to dir (the exercise root), skipping test files
+// and anything matched by a .exercismignore in dir.
+func CollectSolutionFiles(dir string) (map[string]string, error) {
+	ignored, err := readIgnorePatterns(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]string{}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		base := filepath.Base(path)
+		if base == exercismIgnoreFile || IsTest(rel) || matchesIgnorePattern(base, ignored) || matchesIgnorePattern(rel, ignored) {
+			return nil
+		}
+
+		code, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[rel] = string(code)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// readIgnorePatterns reads dir's .exercismignore, if any, returning one
+// pattern per non-blank, non-comment line.
+func readIgnorePatterns(dir string) ([]string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, exercismIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var patterns []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, nil
+}
+
+// matchesIgnorePattern reports whether name matches any of the given
+// gitignore-style glob patterns.
+func matchesIgnorePattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -1,19 +1,21 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 
 	"github.com/codegangsta/cli"
+	"github.com/exercism/cli/api"
 	"github.com/exercism/cli/config"
 	"github.com/exercism/cli/handlers"
+	internalapi "github.com/exercism/cli/internal/api"
+	"github.com/exercism/cli/internal/workspace"
+	"github.com/exercism/cli/output"
 )
 
 const (
@@ -34,6 +36,7 @@ const (
 	descUnsubmit  = "Deletes the most recently submitted iteration."
 	descLogin     = "DEPRECATED: Interactively saves exercism.io api credentials."
 	descLogout    = "DEPRECATED: Clear exercism.io api credentials"
+	descStatus    = "Shows which fetched exercise files are new, updated, dirty or stale."
 
 	descLongRestore = "Restore will pull the latest revisions of exercises that have already been submitted. It will *not* overwrite existing files. If you have made changes to a file and have not submitted it, and you're trying to restore the last submitted version, first move that file out of the way, then call restore."
 )
@@ -44,24 +47,6 @@ var (
 	UserAgent = fmt.Sprintf("github.com/exercism/cli v%s (%s/%s)", Version, runtime.GOOS, runtime.GOARCH)
 )
 
-var FetchEndpoints = map[string]string{
-	"current":  "/api/v1/user/assignments/current",
-	"next":     "/api/v1/user/assignments/next",
-	"restore":  "/api/v1/user/assignments/restore",
-	"exercise": "/api/v1/assignments",
-}
-
-var testExtensions = map[string]string{
-	"ruby":    "_test.rb",
-	"js":      ".spec.js",
-	"elixir":  "_test.exs",
-	"clojure": "_test.clj",
-	"python":  "_test.py",
-	"go":      "_test.go",
-	"haskell": "_test.hs",
-	"cpp":     "_test.cpp",
-}
-
 func main() {
 	app := cli.NewApp()
 	app.Name = "exercism"
@@ -72,6 +57,11 @@ func main() {
 			Name:  "config, c",
 			Usage: "path to config file",
 		},
+		cli.StringFlag{
+			Name:  "output",
+			Value: string(output.Text),
+			Usage: "output format: text, json, or github",
+		},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -108,7 +98,7 @@ func main() {
 			Name:      "fetch",
 			ShortName: "f",
 			Usage:     descFetch,
-			Action:    handlers.Fetch,
+			Action:    fetchAction,
 		},
 		{
 			Name:      "login",
@@ -127,89 +117,24 @@ func main() {
 			ShortName:   "r",
 			Usage:       descRestore,
 			Description: descLongRestore,
-			Action:      handlers.Restore,
+			Action:      restoreAction,
 		},
 		{
 			Name:      "submit",
 			ShortName: "s",
 			Usage:     descSubmit,
-			Action: func(ctx *cli.Context) {
-				if len(ctx.Args()) == 0 {
-					fmt.Println("Please enter a file name")
-					return
-				}
-
-				c, err := config.Read(ctx.GlobalString("config"))
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-
-				if !c.IsAuthenticated() {
-					fmt.Println(msgPleaseAuthenticate)
-					return
-				}
-
-				filename := ctx.Args()[0]
-
-				// Make filename relative to config.Dir.
-				absPath, err := absolutePath(filename)
-				if err != nil {
-					fmt.Printf("Couldn't find %v: %v\n", filename, err)
-					return
-				}
-				exDir := c.Dir + string(filepath.Separator)
-				if !strings.HasPrefix(absPath, exDir) {
-					fmt.Printf("%v is not under your exercism project path (%v)\n", absPath, exDir)
-					return
-				}
-				filename = absPath[len(exDir):]
-
-				if IsTest(filename) {
-					fmt.Println("It looks like this is a test, please submit a solution.")
-					return
-				}
-
-				code, err := ioutil.ReadFile(absPath)
-				if err != nil {
-					fmt.Printf("Error reading %v: %v\n", absPath, err)
-					return
-				}
-
-				response, err := SubmitAssignment(c, filename, code)
-				if err != nil {
-					fmt.Printf("There was an issue with your submission: %v\n", err)
-					return
-				}
-
-				fmt.Printf("For feedback on your submission visit %s%s%s\n",
-					c.Hostname, "/submissions/", response.ID)
-
-			},
+			Action:    submitAction,
+		},
+		{
+			Name:   "status",
+			Usage:  descStatus,
+			Action: handlers.Status,
 		},
 		{
 			Name:      "unsubmit",
 			ShortName: "u",
 			Usage:     descUnsubmit,
-			Action: func(ctx *cli.Context) {
-				c, err := config.Read(ctx.GlobalString("config"))
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-
-				if !c.IsAuthenticated() {
-					fmt.Println(msgPleaseAuthenticate)
-					return
-				}
-
-				err = UnsubmitAssignment(c)
-				if err != nil {
-					fmt.Println(err)
-					return
-				}
-				fmt.Println("The last submission was successfully deleted.")
-			},
+			Action:    unsubmitAction,
 		},
 	}
 	err := app.Run(os.Args)
@@ -219,220 +144,188 @@ func main() {
 	}
 }
 
-func absolutePath(path string) (string, error) {
-	path, err := filepath.Abs(path)
-	if err != nil {
-		return "", err
-	}
-	return filepath.EvalSymlinks(path)
-}
+func submitAction(ctx *cli.Context) {
+	out := output.New(ctx.GlobalString("output"))
 
-type submitResponse struct {
-	ID             string `json:"id"`
-	Status         string `json:"status"`
-	Language       string `json:"language"`
-	Exercise       string `json:"exercise"`
-	SubmissionPath string `json:"submission_path"`
-	Error          string `json:"error"`
-}
-
-type submitRequest struct {
-	Key  string `json:"key"`
-	Code string `json:"code"`
-	Path string `json:"path"`
-}
+	if len(ctx.Args()) == 0 {
+		out.Error(output.Event{Name: "submit", Message: "Please enter a file or directory name"})
+		return
+	}
 
-func FetchAssignments(c *config.Config, path string) ([]Assignment, error) {
-	url := fmt.Sprintf("%s%s?key=%s", c.Hostname, path, c.APIKey)
-	req, err := http.NewRequest("GET", url, nil)
+	c, err := config.Read(ctx.GlobalString("config"))
 	if err != nil {
-		return nil, err
+		out.Error(output.Event{Name: "submit", Message: err.Error()})
+		return
 	}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		err = fmt.Errorf("Error fetching assignments: [%v]", err)
-		return nil, err
+	if !c.IsAuthenticated() {
+		out.Error(output.Event{Name: "submit", Message: msgPleaseAuthenticate})
+		return
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
+	exDir := c.Dir + string(filepath.Separator)
+	files := map[string]string{}
+	var root string
 
-	if err != nil {
-		err = fmt.Errorf("Error fetching assignments: [%v]", err)
-		return nil, err
-	}
+	for _, arg := range ctx.Args() {
+		absPath, err := workspace.AbsolutePath(arg)
+		if err != nil {
+			out.Error(output.Event{Name: "submit", Path: arg, Message: fmt.Sprintf("Couldn't find %v: %v", arg, err)})
+			return
+		}
+		if !strings.HasPrefix(absPath, exDir) {
+			out.Error(output.Event{Name: "submit", Path: absPath, Message: fmt.Sprintf("%v is not under your exercism project path (%v)", absPath, exDir)})
+			return
+		}
+
+		info, err := os.Stat(absPath)
+		if err != nil {
+			out.Error(output.Event{Name: "submit", Path: arg, Message: fmt.Sprintf("Couldn't find %v: %v", arg, err)})
+			return
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		var apiError struct {
-			Error string `json:"error"`
+		if info.IsDir() {
+			if root == "" {
+				root = absPath[len(exDir):]
+			}
+			found, err := workspace.CollectSolutionFiles(absPath)
+			if err != nil {
+				out.Error(output.Event{Name: "submit", Path: absPath, Message: fmt.Sprintf("Error reading %v: %v", absPath, err)})
+				return
+			}
+			for name, text := range found {
+				files[name] = text
+			}
+			continue
+		}
+
+		name := absPath[len(exDir):]
+		if workspace.IsTest(name) {
+			out.Error(output.Event{Name: "submit", Path: name, Message: "It looks like this is a test, please submit a solution."})
+			return
 		}
-		err = json.Unmarshal(body, &apiError)
+		if root == "" {
+			root = filepath.Dir(name)
+		}
+
+		rel, err := filepath.Rel(root, name)
 		if err != nil {
-			err = fmt.Errorf("Error parsing API response: [%v]", err)
-			return nil, err
+			out.Error(output.Event{Name: "submit", Path: name, Message: fmt.Sprintf("Error resolving %v relative to %v: %v", name, root, err)})
+			return
 		}
 
-		err = fmt.Errorf("Error fetching assignments. HTTP Status Code: %d\n%s", resp.StatusCode, apiError.Error)
-		return nil, err
+		code, err := ioutil.ReadFile(absPath)
+		if err != nil {
+			out.Error(output.Event{Name: "submit", Path: absPath, Message: fmt.Sprintf("Error reading %v: %v", absPath, err)})
+			return
+		}
+		files[rel] = string(code)
 	}
 
-	var fr struct {
-		Assignments []Assignment
+	if len(files) == 0 {
+		out.Warning(output.Event{Name: "submit", Message: "No solution files found to submit."})
+		return
 	}
 
-	err = json.Unmarshal(body, &fr)
+	api := internalapi.NewClient(c, UserAgent)
+	response, err := api.SubmitAssignment(context.Background(), root, files)
 	if err != nil {
-		err = fmt.Errorf("Error parsing API response: [%v]", err)
-		return nil, err
+		out.Error(output.Event{Name: "submit", Path: root, Message: fmt.Sprintf("There was an issue with your submission: %v", err)})
+		return
 	}
 
-	return fr.Assignments, nil
+	out.Notice(output.Event{
+		Name:    "submitted",
+		Path:    root,
+		Message: fmt.Sprintf("For feedback on your submission visit %s/submissions/%s", c.Hostname, response.ID),
+	})
 }
 
-func UnsubmitAssignment(c *config.Config) error {
-	path := "api/v1/user/assignments"
+func unsubmitAction(ctx *cli.Context) {
+	out := output.New(ctx.GlobalString("output"))
 
-	url := fmt.Sprintf("%s/%s?key=%s", c.Hostname, path, c.APIKey)
-
-	req, err := http.NewRequest("DELETE", url, nil)
+	c, err := config.Read(ctx.GlobalString("config"))
 	if err != nil {
-		return err
+		out.Error(output.Event{Name: "unsubmit", Message: err.Error()})
+		return
 	}
 
-	req.Header.Set("User-Agent", UserAgent)
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		err = fmt.Errorf("Error destroying submission: [%v]", err)
-		return err
+	if !c.IsAuthenticated() {
+		out.Error(output.Event{Name: "unsubmit", Message: msgPleaseAuthenticate})
+		return
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return err
-	}
-
-	if resp.StatusCode != http.StatusNoContent {
-
-		var ur struct {
-			Error string
-		}
-
-		err = json.Unmarshal(body, &ur)
-		if err != nil {
-			return err
-		}
-
-		err = fmt.Errorf("Status: %d, Error: %v", resp.StatusCode, ur.Error)
-		return err
+	api := internalapi.NewClient(c, UserAgent)
+	if err := api.UnsubmitAssignment(context.Background()); err != nil {
+		out.Error(output.Event{Name: "unsubmit", Message: err.Error()})
+		return
 	}
-
-	return nil
+	out.Notice(output.Event{Name: "unsubmitted", Message: "The last submission was successfully deleted."})
 }
-func SubmitAssignment(c *config.Config, filePath string, code []byte) (*submitResponse, error) {
-	path := "api/v1/user/assignments"
 
-	url := fmt.Sprintf("%s/%s", c.Hostname, path)
+func fetchAction(ctx *cli.Context) {
+	out := output.New(ctx.GlobalString("output"))
 
-	submission := submitRequest{Key: c.APIKey, Code: string(code), Path: filePath}
-	submissionJSON, err := json.Marshal(submission)
+	c, err := config.Read(ctx.GlobalString("config"))
 	if err != nil {
-		return nil, err
+		out.Error(output.Event{Name: "fetch", Message: err.Error()})
+		return
 	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewReader(submissionJSON))
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("User-Agent", UserAgent)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		err = fmt.Errorf("Error posting assignment: [%v]", err)
-		return nil, err
+	if !c.IsAuthenticated() {
+		out.Error(output.Event{Name: "fetch", Message: msgPleaseAuthenticate})
+		return
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
-	if err != nil {
-		return nil, err
-	}
+	ic := internalapi.NewClient(c, UserAgent)
 
-	var r submitResponse
-	if resp.StatusCode != http.StatusCreated {
-		err = json.Unmarshal(body, &r)
+	var assignments []internalapi.Assignment
+	for _, path := range []string{api.DefaultEndpoints.Current, api.DefaultEndpoints.Next} {
+		fetched, err := ic.FetchAssignments(context.Background(), path)
 		if err != nil {
-			return nil, err
+			out.Error(output.Event{Name: "fetch", Message: err.Error()})
+			return
 		}
-		err = fmt.Errorf("Status: %d, Error: %v", resp.StatusCode, r)
-		return nil, err
+		assignments = append(assignments, fetched...)
 	}
 
-	err = json.Unmarshal(body, &r)
-	if err != nil {
-		return nil, fmt.Errorf("Error parsing API response: [%v]", err)
-	}
-	return &r, nil
+	saveAssignments(c, assignments, out)
 }
 
-type Assignment struct {
-	Track   string
-	Slug    string
-	Files   map[string]string
-	IsFresh bool `json:"fresh"`
-}
-
-func SaveAssignment(dir string, a Assignment) error {
-	root := fmt.Sprintf("%s/%s/%s", dir, a.Track, a.Slug)
-
-	for name, text := range a.Files {
-		file := fmt.Sprintf("%s/%s", root, name)
-		dir := filepath.Dir(file)
-		err := os.MkdirAll(dir, 0755)
-		if err != nil {
-			return fmt.Errorf("Error making directory %v: [%v]", dir, err)
-		}
-		if _, err = os.Stat(file); err != nil {
-			if os.IsNotExist(err) {
-				err = ioutil.WriteFile(file, []byte(text), 0644)
-				if err != nil {
-					return fmt.Errorf("Error writing file %v: [%v]", name, err)
-				}
-			}
-		}
-	}
+func restoreAction(ctx *cli.Context) {
+	out := output.New(ctx.GlobalString("output"))
 
-	fresh := " "
-	if a.IsFresh {
-		fresh = "*"
+	c, err := config.Read(ctx.GlobalString("config"))
+	if err != nil {
+		out.Error(output.Event{Name: "restore", Message: err.Error()})
+		return
 	}
-	fmt.Println(fresh, a.Track, "-", a.Slug)
-
-	return nil
-}
-
-func FetchEndpoint(args []string) string {
-	if len(args) == 0 {
-		return FetchEndpoints["current"]
+	if !c.IsAuthenticated() {
+		out.Error(output.Event{Name: "restore", Message: msgPleaseAuthenticate})
+		return
 	}
 
-	endpoint := FetchEndpoints["exercise"]
-	for _, arg := range args {
-		endpoint = fmt.Sprintf("%s/%s", endpoint, arg)
+	ic := internalapi.NewClient(c, UserAgent)
+	assignments, err := ic.FetchAssignments(context.Background(), api.DefaultEndpoints.Restore)
+	if err != nil {
+		out.Error(output.Event{Name: "restore", Message: err.Error()})
+		return
 	}
 
-	return endpoint
+	saveAssignments(c, assignments, out)
 }
 
-func IsTest(filename string) bool {
-	for _, ext := range testExtensions {
-		if strings.LastIndex(filename, ext) > 0 {
-			return true
+// saveAssignments writes each assignment to c.Dir, reporting errors
+// through out rather than aborting the rest of the batch. Each
+// assignment is wrapped in its own ::group::, so a GitHub Actions log
+// collapses to one line per track/slug, and a summary line is appended
+// to $GITHUB_STEP_SUMMARY once the batch finishes.
+func saveAssignments(c *config.Config, assignments []internalapi.Assignment, out *output.Writer) {
+	for _, a := range assignments {
+		out.Group(fmt.Sprintf("%s/%s", a.Track, a.Slug))
+		if err := workspace.SaveAssignment(c.Dir, a, out); err != nil {
+			out.Error(output.Event{Name: "fetch", Track: a.Track, Slug: a.Slug, Message: err.Error()})
 		}
+		out.EndGroup()
 	}
-	return false
+	out.Summary(fmt.Sprintf("Fetched %d exercise(s).", len(assignments)))
 }
@@ -0,0 +1,125 @@
+// Package output renders progress and result messages in the format
+// requested by the --output flag, so `exercism submit`/`fetch`/etc. can
+// run unattended in CI as well as interactively at a terminal.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Mode selects how events are rendered.
+type Mode string
+
+const (
+	// Text prints a short human-readable line per event, the default.
+	Text Mode = "text"
+	// JSON prints one JSON object per event, suitable for piping to jq.
+	JSON Mode = "json"
+	// GitHub emits GitHub Actions workflow commands, so a run shows
+	// annotations on the PR that triggered it.
+	GitHub Mode = "github"
+)
+
+// Writer emits Events in its configured Mode. The zero value, and a nil
+// *Writer, both behave like a Text writer.
+type Writer struct {
+	Mode Mode
+}
+
+// New builds a Writer for the given mode string, falling back to Text
+// for an empty or unrecognized value.
+func New(mode string) *Writer {
+	switch Mode(mode) {
+	case JSON:
+		return &Writer{Mode: JSON}
+	case GitHub:
+		return &Writer{Mode: GitHub}
+	default:
+		return &Writer{Mode: Text}
+	}
+}
+
+// Event is one thing that happened during a command, e.g. a file being
+// saved or a submission completing.
+type Event struct {
+	Name    string `json:"event"`
+	Track   string `json:"track,omitempty"`
+	Slug    string `json:"slug,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// Notice reports routine progress, e.g. a file being fetched.
+func (w *Writer) Notice(e Event) { w.emit(e, "notice") }
+
+// Warning reports something recoverable, e.g. a dirty file being left
+// alone.
+func (w *Writer) Warning(e Event) { w.emit(e, "warning") }
+
+// Error reports a failure.
+func (w *Writer) Error(e Event) { w.emit(e, "error") }
+
+func (w *Writer) mode() Mode {
+	if w == nil {
+		return Text
+	}
+	return w.Mode
+}
+
+func (w *Writer) emit(e Event, level string) {
+	switch w.mode() {
+	case JSON:
+		b, err := json.Marshal(e)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		fmt.Println(string(b))
+	case GitHub:
+		if e.Path == "" {
+			fmt.Printf("::%s::%s\n", level, e.Message)
+			return
+		}
+		fmt.Printf("::%s file=%s::%s\n", level, e.Path, e.Message)
+	default:
+		fmt.Println(e.Message)
+	}
+}
+
+// Group opens a collapsible section (e.g. one per track) in github mode;
+// it's a no-op in every other mode.
+func (w *Writer) Group(name string) {
+	if w.mode() == GitHub {
+		fmt.Printf("::group::%s\n", name)
+	}
+}
+
+// EndGroup closes a section opened with Group.
+func (w *Writer) EndGroup() {
+	if w.mode() == GitHub {
+		fmt.Println("::endgroup::")
+	}
+}
+
+// Summary appends text to $GITHUB_STEP_SUMMARY in github mode, if that
+// variable is set; it's a no-op in every other mode.
+func (w *Writer) Summary(text string) {
+	if w.mode() != GitHub {
+		return
+	}
+
+	path := os.Getenv("GITHUB_STEP_SUMMARY")
+	if path == "" {
+		return
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	fmt.Fprintln(f, text)
+}